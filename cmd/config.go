@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/t-eckert/nb/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Get, set, and list nb configuration values.",
+	Long: `The config command reads and writes values in the nb configuration file
+(~/.nb.yaml by default). Recognized keys are editor, root, port, remote,
+templatesDir, dateFormat, domain, gitToken, and syncMessageTemplate.`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the value of a configuration key.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Println(viper.Get(args[0]))
+		return nil
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration key and persist it to the config file.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return config.Set(args[0], args[1])
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all configuration keys and their current values.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keys := config.Keys()
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("%s: %v\n", key, viper.Get(key))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configGetCmd, configSetCmd, configListCmd)
+}
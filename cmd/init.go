@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	survey "github.com/AlecAivazis/survey/v2"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/spf13/cobra"
+	"github.com/t-eckert/nb/config"
+)
+
+const defaultDailyTemplate = `# {{.DateTitle}}
+
+## Tasks
+
+`
+
+// initAnswers holds every question `nb init` asks, whether gathered
+// interactively or from --yes flags.
+type initAnswers struct {
+	Root   string
+	Editor string
+	Port   int
+	Git    bool
+	Remote string
+	Format string
+}
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create and configure a new notebook.",
+	Long: `Init walks you through creating a notebook: its root directory, editor,
+port, and (optionally) a git repository and remote. It scaffolds the
+Log/ and templates/ directories and writes your answers to ~/.nb.yaml
+(or ~/.nb.toml).
+
+Pass --yes to run non-interactively, using the other flags in place of
+prompts, which is useful for scripting.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		yes, err := cmd.Flags().GetBool("yes")
+		if err != nil {
+			return err
+		}
+
+		answers, err := gatherInitAnswers(cmd, yes)
+		if err != nil {
+			return err
+		}
+
+		if err := scaffoldNotebook(answers); err != nil {
+			return err
+		}
+
+		return persistInitAnswers(answers)
+	},
+}
+
+func gatherInitAnswers(cmd *cobra.Command, yes bool) (*initAnswers, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	answers := &initAnswers{
+		Root:   fmt.Sprintf("%s/Notebook", home),
+		Editor: "nvim",
+		Port:   3000,
+		Format: "yaml",
+	}
+
+	if yes {
+		if v, _ := cmd.Flags().GetString("root"); v != "" {
+			answers.Root = v
+		}
+		if v, _ := cmd.Flags().GetString("editor"); v != "" {
+			answers.Editor = v
+		}
+		if v, _ := cmd.Flags().GetInt("port"); v != 0 {
+			answers.Port = v
+		}
+		answers.Git, _ = cmd.Flags().GetBool("git")
+		answers.Remote, _ = cmd.Flags().GetString("remote")
+		if v, _ := cmd.Flags().GetString("format"); v != "" {
+			answers.Format = v
+		}
+		return answers, nil
+	}
+
+	if err := survey.AskOne(&survey.Input{
+		Message: "Where should your notebook live?",
+		Default: answers.Root,
+	}, &answers.Root); err != nil {
+		return nil, err
+	}
+
+	if err := survey.AskOne(&survey.Input{
+		Message: "Which editor should nb open notes with?",
+		Default: answers.Editor,
+	}, &answers.Editor); err != nil {
+		return nil, err
+	}
+
+	portStr := strconv.Itoa(answers.Port)
+	if err := survey.AskOne(&survey.Input{
+		Message: "Which port should `nb serve` use?",
+		Default: portStr,
+	}, &portStr); err != nil {
+		return nil, err
+	}
+	if answers.Port, err = strconv.Atoi(portStr); err != nil {
+		return nil, fmt.Errorf("port must be a number: %w", err)
+	}
+
+	if err := survey.AskOne(&survey.Confirm{
+		Message: "Initialize a git repository for the notebook?",
+		Default: false,
+	}, &answers.Git); err != nil {
+		return nil, err
+	}
+
+	if answers.Git {
+		if err := survey.AskOne(&survey.Input{
+			Message: "Git remote URL (leave blank to skip)",
+		}, &answers.Remote); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := survey.AskOne(&survey.Select{
+		Message: "Config file format:",
+		Options: []string{"yaml", "toml"},
+		Default: answers.Format,
+	}, &answers.Format); err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+// scaffoldNotebook creates the notebook's root, Log/, and templates/
+// directories, seeds a default daily-log template, and optionally
+// initializes a git repository and remote.
+func scaffoldNotebook(answers *initAnswers) error {
+	dirs := []string{
+		answers.Root,
+		filepath.Join(answers.Root, "Log"),
+		filepath.Join(answers.Root, "templates"),
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	templatePath := filepath.Join(answers.Root, "templates", "daily.md")
+	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
+		if err := os.WriteFile(templatePath, []byte(defaultDailyTemplate), 0o644); err != nil {
+			return err
+		}
+	}
+
+	if !answers.Git {
+		return nil
+	}
+
+	repo, err := git.PlainInit(answers.Root, false)
+	if err != nil {
+		if err != git.ErrRepositoryAlreadyExists {
+			return err
+		}
+		if repo, err = git.PlainOpen(answers.Root); err != nil {
+			return err
+		}
+	}
+
+	if answers.Remote == "" {
+		return nil
+	}
+
+	_, err = repo.CreateRemote(&gitconfig.RemoteConfig{
+		Name: "origin",
+		URLs: []string{answers.Remote},
+	})
+	if err != nil && err != git.ErrRemoteExists {
+		return err
+	}
+
+	return nil
+}
+
+func persistInitAnswers(answers *initAnswers) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(home, fmt.Sprintf(".nb.%s", answers.Format))
+
+	return config.WriteConfig(path, map[string]string{
+		config.KeyRoot:   answers.Root,
+		config.KeyEditor: answers.Editor,
+		config.KeyPort:   strconv.Itoa(answers.Port),
+		config.KeyRemote: answers.Remote,
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(initCmd)
+
+	initCmd.Flags().Bool("yes", false, "Run non-interactively, using the flags below instead of prompts.")
+	initCmd.Flags().String("root", "", "Notebook root directory.")
+	initCmd.Flags().String("editor", "", "Editor command.")
+	initCmd.Flags().Int("port", 0, "Dev server port.")
+	initCmd.Flags().Bool("git", false, "Initialize a git repository for the notebook.")
+	initCmd.Flags().String("remote", "", "Git remote URL.")
+	initCmd.Flags().String("format", "yaml", "Config file format: yaml or toml.")
+}
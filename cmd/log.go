@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"log"
-
 	"github.com/spf13/cobra"
 	noteLog "github.com/t-eckert/nb/log"
 )
@@ -13,16 +11,24 @@ var logCmd = &cobra.Command{
 	Long: `The log command will create and open a daily log file based on a template.
 
 The offset flag may be passed to edit a daily log offset by the given number of days.
-If a log already exists for a given day, it will be opened.`,
+If a log already exists for a given day, it will be opened.
+
+The template used to create new logs can be changed with --template, and
+defaults to "daily". Templates are loaded from <root>/templates, or the
+templatesDir config override.`,
 
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		open, err := cmd.Flags().GetBool("open")
 		if err != nil {
-			log.Fatalf(err.Error())
+			return err
 		}
-		if err := noteLog.Log(open, args...); err != nil {
-			log.Fatal(err.Error())
+
+		template, err := cmd.Flags().GetString("template")
+		if err != nil {
+			return err
 		}
+
+		return noteLog.Log(open, template, args...)
 	},
 }
 
@@ -30,4 +36,5 @@ func init() {
 	rootCmd.AddCommand(logCmd)
 
 	logCmd.PersistentFlags().BoolP("open", "o", true, "Whether or not to open the editor to the log.")
+	logCmd.Flags().String("template", "daily", "Name of the template used to create new logs.")
 }
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/t-eckert/nb/config"
+	"github.com/t-eckert/nb/editor"
+	"github.com/t-eckert/nb/internal/errs"
+	"github.com/t-eckert/nb/templates"
+)
+
+var noteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Create notes from templates other than the daily log.",
+	Long: `Note renders one of your templates into a new file and opens it, so you
+can keep templates for meeting notes, book notes, or anything else
+alongside the daily log template.`,
+}
+
+var noteNewCmd = &cobra.Command{
+	Use:   "new <name>",
+	Short: "Create a note from the \"note\" template.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		open, err := cmd.Flags().GetBool("open")
+		if err != nil {
+			return err
+		}
+
+		return newNote(args[0], "note", open)
+	},
+}
+
+var noteFromCmd = &cobra.Command{
+	Use:   "from <template>",
+	Short: "Create a note from the named template.",
+	Long: `From renders <template>.md into <root>/<template>-<date>.md, so notes
+made from the same template on different days don't collide.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		open, err := cmd.Flags().GetBool("open")
+		if err != nil {
+			return err
+		}
+
+		data := templates.DataFor(0)
+		name := fmt.Sprintf("%s-%s", args[0], data.Date)
+
+		return renderNote(name, args[0], data, open)
+	},
+}
+
+// newNote renders template into <root>/<name>.md and optionally opens it.
+func newNote(name, template string, open bool) error {
+	return renderNote(name, template, templates.DataFor(0), open)
+}
+
+func renderNote(name, template string, data templates.Data, open bool) error {
+	root, err := config.GetRootDir()
+	if err != nil {
+		return errs.ConfigMissing(err)
+	}
+
+	dir, err := templates.Dir(root)
+	if err != nil {
+		return errs.ConfigMissing(err)
+	}
+
+	content, err := templates.Render(dir, template, data)
+	if err != nil {
+		return errs.TemplateFailed(err)
+	}
+
+	path := filepath.Join(root, name+".md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return err
+	}
+
+	if !open {
+		return nil
+	}
+
+	if err := editor.Open(path); err != nil {
+		return errs.EditorFailed(err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(noteCmd)
+	noteCmd.AddCommand(noteNewCmd, noteFromCmd)
+
+	noteCmd.PersistentFlags().BoolP("open", "o", true, "Whether or not to open the editor to the note.")
+}
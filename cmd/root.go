@@ -1,45 +1,68 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"os"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"github.com/spf13/viper"
 	"github.com/t-eckert/nb/config"
 	"github.com/t-eckert/nb/editor"
+	"github.com/t-eckert/nb/internal/errs"
 )
 
 var cfgFile string
+var debug bool
+
+// usageMode is set to "dev" at build time (-ldflags "-X
+// github.com/t-eckert/nb/cmd.usageMode=dev") to always show full error
+// diagnostics, regardless of --debug.
+var usageMode = "prod"
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "nb",
 	Short: "NotaBene is a command line application for taking notes",
 	Long:  `NotaBene is a command line application for taking notes`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := editor.Open(config.RootDir); err != nil {
-			log.Fatalf("Could not open notebook directory: %v", err)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := config.GetRootDir()
+		if err != nil {
+			return errs.ConfigMissing(err)
+		}
+
+		if err := editor.Open(root); err != nil {
+			return errs.EditorFailed(err)
 		}
+
+		return nil
 	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	cobra.CheckErr(rootCmd.Execute())
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil {
+		printError(cmd, err)
+		os.Exit(exitCode(err))
+	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.nb.yaml)")
+	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "d", false, "Show the full error cause and command path when a command fails.")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -48,6 +71,8 @@ func init() {
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
+	config.SetDefaults()
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
@@ -57,15 +82,57 @@ func initConfig() {
 		cobra.CheckErr(err)
 
 		// Search config in home directory with name ".nb" (without extension).
+		// No SetConfigType: viper detects the format (yaml, toml, ...) from
+		// whichever extension `nb init` wrote.
 		viper.AddConfigPath(home)
-		viper.SetConfigType("yaml")
 		viper.SetConfigName(".nb")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	// Environment variables like NB_EDITOR or NB_ROOT override the config file.
+	viper.SetEnvPrefix("nb")
+	viper.AutomaticEnv()
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
+
+// verbose is true when the user should see full diagnostics: either they
+// passed --debug, or this is a dev build.
+func verbose() bool {
+	return debug || usageMode == "dev"
+}
+
+// printError formats err for the user: a typed *errs.Error gets its
+// message and remediation hint in color, with the underlying cause and
+// failing command path added in verbose mode. Any other error is printed
+// as-is.
+func printError(cmd *cobra.Command, err error) {
+	var nbErr *errs.Error
+	if errors.As(err, &nbErr) {
+		fmt.Fprintln(os.Stderr, color.RedString("Error:"), nbErr.Message)
+		if nbErr.Remediation != "" {
+			fmt.Fprintln(os.Stderr, color.YellowString("Try:"), nbErr.Remediation)
+		}
+		if verbose() && nbErr.Cause != nil {
+			fmt.Fprintf(os.Stderr, "Cause: %v\n", nbErr.Cause)
+		}
+	} else {
+		fmt.Fprintln(os.Stderr, color.RedString("Error:"), err)
+	}
+
+	if verbose() && cmd != nil {
+		fmt.Fprintf(os.Stderr, "Command: %s\n", cmd.CommandPath())
+	}
+}
+
+// exitCode returns the exit code an error should terminate the process
+// with: a typed *errs.Error's code, or 1 for anything else.
+func exitCode(err error) int {
+	var nbErr *errs.Error
+	if errors.As(err, &nbErr) {
+		return int(nbErr.Code)
+	}
+	return int(errs.CodeUnknown)
+}
@@ -8,13 +8,24 @@ import (
 // serveCmd represents the serve command
 var serveCmd = &cobra.Command{
 	Use:   "serve",
-	Short: "",
-	Long:  ``,
-	Run: func(cmd *cobra.Command, args []string) {
-		serve.Serve()
+	Short: "Serve the notebook as a website.",
+	Long: `Serve renders the notebook's Markdown files to HTML and serves them
+over HTTP, alongside an Atom feed, an RSS feed, and a sitemap.
+
+Pass --dev to watch the notebook for changes and automatically reload
+the browser as files are edited.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dev, err := cmd.Flags().GetBool("dev")
+		if err != nil {
+			return err
+		}
+
+		return serve.Serve(dev)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().Bool("dev", false, "Watch the notebook for changes and reload the browser automatically.")
 }
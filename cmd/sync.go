@@ -2,49 +2,111 @@ package cmd
 
 import (
 	"fmt"
-	"log"
-	"os"
-	"os/exec"
-	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/t-eckert/nb/config"
+	"github.com/t-eckert/nb/internal/errs"
+	"github.com/t-eckert/nb/sync"
 )
 
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Sync the notebook to remote.",
-	Long: `Sync will add all files to the commit, commit with the message
-"Sync YYYY-MM-DD", and push the commit to the remote.`,
+	Long: `Sync stages every change in the notebook, commits it with a configurable
+message, and pushes the commit to the remote.`,
 
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		root, err := config.GetRootDir()
 		if err != nil {
-			log.Fatal(err)
+			return errs.ConfigMissing(err)
 		}
 
-		err = os.Chdir(root)
+		repo, err := sync.Open(root)
 		if err != nil {
-			log.Fatal(err)
+			return err
 		}
 
-		err = exec.Command("git", "add", ".").Run()
+		if pullFirst, _ := cmd.Flags().GetBool("pull-first"); pullFirst {
+			if err := repo.Pull(); err != nil {
+				return errs.GitPullFailed(err)
+			}
+		}
+
+		message, err := cmd.Flags().GetString("message")
 		if err != nil {
-			log.Fatal(err)
+			return err
+		}
+		if message == "" {
+			message, err = sync.Message()
+			if err != nil {
+				return err
+			}
 		}
 
-		err = exec.Command("git", "commit", "-m", fmt.Sprintf("Sync %s", time.Now().Format("2006-01-02"))).Run()
+		committed, err := repo.Commit(message)
 		if err != nil {
-			log.Fatal(err)
+			return err
+		}
+		if committed {
+			fmt.Println(message)
 		}
 
-		err = exec.Command("git", "push").Run()
+		if noPush, _ := cmd.Flags().GetBool("no-push"); noPush {
+			return nil
+		}
+
+		if err := repo.Push(); err != nil {
+			return errs.GitPushFailed(err)
+		}
+
+		return nil
+	},
+}
+
+var syncStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report the notebook repository's dirty files and ahead/behind counts.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := config.GetRootDir()
 		if err != nil {
-			log.Fatal(err)
+			return errs.ConfigMissing(err)
 		}
+
+		repo, err := sync.Open(root)
+		if err != nil {
+			return err
+		}
+
+		status, err := repo.Status()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Ahead %d, behind %d\n", status.Ahead, status.Behind)
+
+		if len(status.Dirty) > 0 {
+			fmt.Println("Dirty files:")
+			for _, path := range status.Dirty {
+				fmt.Printf("  %s\n", path)
+			}
+		}
+
+		if len(status.Conflicts) > 0 {
+			fmt.Println("Conflicts:")
+			for _, path := range status.Conflicts {
+				fmt.Printf("  %s\n", path)
+			}
+		}
+
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(syncCmd)
+	syncCmd.AddCommand(syncStatusCmd)
+
+	syncCmd.Flags().StringP("message", "m", "", "Commit message. Defaults to the configured sync message template.")
+	syncCmd.Flags().Bool("no-push", false, "Commit without pushing to the remote.")
+	syncCmd.Flags().Bool("pull-first", false, "Pull and fast-forward onto the remote before committing.")
 }
@@ -3,18 +3,158 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
 )
 
+// Keys recognized in ~/.nb.yaml and as NB_* environment variable overrides.
 const (
-	Editor = "nvim"
-	Port   = 3000
+	KeyEditor       = "editor"
+	KeyRoot         = "root"
+	KeyPort         = "port"
+	KeyRemote       = "remote"
+	KeyTemplatesDir = "templatesDir"
+	KeyDateFormat   = "dateFormat"
+	KeyDomain       = "domain"
+	KeyGitToken     = "gitToken"
+	KeySyncMessage  = "syncMessageTemplate"
 )
 
+// SetDefaults registers the fallback value for every config key. It should
+// be called once before the config file and environment are read, so that
+// keys left unset by the user still resolve to something sensible.
+func SetDefaults() {
+	home, _ := os.UserHomeDir()
+
+	viper.SetDefault(KeyEditor, "nvim")
+	viper.SetDefault(KeyRoot, fmt.Sprintf("%s/Notebook", home))
+	viper.SetDefault(KeyPort, 3000)
+	viper.SetDefault(KeyRemote, "")
+	viper.SetDefault(KeyTemplatesDir, "")
+	viper.SetDefault(KeyDateFormat, "2006-01-02")
+	viper.SetDefault(KeyDomain, "localhost")
+	viper.SetDefault(KeyGitToken, "")
+	viper.SetDefault(KeySyncMessage, "Sync {{.Date}}")
+}
+
+// GetEditor returns the command used to open notes, e.g. "nvim" or "code".
+func GetEditor() (string, error) {
+	return viper.GetString(KeyEditor), nil
+}
+
+// GetRootDir returns the path to the notebook's root directory.
 func GetRootDir() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", nil
+	return viper.GetString(KeyRoot), nil
+}
+
+// GetPort returns the port the dev server should listen on.
+func GetPort() (int, error) {
+	return viper.GetInt(KeyPort), nil
+}
+
+// GetRemote returns the configured git remote for `nb sync`.
+func GetRemote() (string, error) {
+	return viper.GetString(KeyRemote), nil
+}
+
+// GetTemplatesDir returns the directory templates are loaded from for both
+// `log` and `note`, overriding the notebook's default <root>/templates.
+func GetTemplatesDir() (string, error) {
+	return viper.GetString(KeyTemplatesDir), nil
+}
+
+// GetDateFormat returns the configured Go time format for dates. Log file
+// names and template dates use the fixed "2006-01-02" layout and do not
+// read this value.
+func GetDateFormat() (string, error) {
+	return viper.GetString(KeyDateFormat), nil
+}
+
+// GetDomain returns the domain used to build stable tag: URIs for the
+// notebook's feeds.
+func GetDomain() (string, error) {
+	return viper.GetString(KeyDomain), nil
+}
+
+// GetGitToken returns the token used to authenticate `nb sync` pushes over
+// HTTPS when no SSH agent is available.
+func GetGitToken() (string, error) {
+	return viper.GetString(KeyGitToken), nil
+}
+
+// GetSyncMessageTemplate returns the text/template used to render `nb sync`
+// commit messages. It is executed with a single ".Date" field.
+func GetSyncMessageTemplate() (string, error) {
+	return viper.GetString(KeySyncMessage), nil
+}
+
+// Set updates a single config key in memory and persists it to the config
+// file, creating ~/.nb.yaml if no config file exists yet. Only the
+// requested key is written to disk.
+func Set(key, value string) error {
+	viper.Set(key, value)
+
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		path = filepath.Join(home, ".nb.yaml")
+		viper.SetConfigFile(path)
+	}
+
+	return persistKeys(path, map[string]string{key: value})
+}
+
+// WriteConfig persists every key in values to path, creating the file if
+// it does not already exist. It is used by `nb init` to write the first
+// config file in the user's chosen format.
+func WriteConfig(path string, values map[string]string) error {
+	for key, value := range values {
+		viper.Set(key, value)
 	}
 
-	return fmt.Sprintf("%s/Notebook", home), nil
+	viper.SetConfigFile(path)
+
+	return persistKeys(path, values)
+}
+
+// persistKeys merges values into whatever is already on disk at path and
+// writes the result back, using a Viper instance of its own. It
+// deliberately does not go through the package's global Viper, whose view
+// is merged with defaults and NB_* environment overrides: writing that
+// merged view would permanently bake a transient environment override
+// (e.g. an NB_GITTOKEN set for one CI run) into the config file.
+func persistKeys(path string, values map[string]string) error {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if _, err := os.Stat(path); err == nil {
+		if err := v.ReadInConfig(); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	for key, value := range values {
+		v.Set(key, value)
+	}
+
+	if err := v.WriteConfig(); err != nil {
+		if os.IsNotExist(err) {
+			return v.SafeWriteConfig()
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Keys returns every recognized config key, in a stable order, for use by
+// `nb config list`.
+func Keys() []string {
+	return []string{KeyEditor, KeyRoot, KeyPort, KeyRemote, KeyTemplatesDir, KeyDateFormat, KeyDomain, KeyGitToken, KeySyncMessage}
 }
@@ -2,9 +2,15 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"testing"
 )
 
+func TestMain(m *testing.M) {
+	SetDefaults()
+	os.Exit(m.Run())
+}
+
 func TestGetEditor(t *testing.T) {
 	expected := "nvim"
 
@@ -16,7 +22,8 @@ func TestGetEditor(t *testing.T) {
 }
 
 func TestGetRootDir(t *testing.T) {
-	expected := "~/notebook"
+	home, _ := os.UserHomeDir()
+	expected := fmt.Sprintf("%s/Notebook", home)
 
 	actual, _ := GetRootDir()
 
@@ -8,7 +8,12 @@ import (
 )
 
 func Open(filePath string) error {
-	cmd := exec.Command(config.Editor, filePath)
+	editor, err := config.GetEditor()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(editor, filePath)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 
@@ -0,0 +1,87 @@
+// Package errs defines the typed errors nb commands return, each carrying
+// a stable exit code and a remediation hint for the user.
+package errs
+
+import "fmt"
+
+// Code is a stable per-class process exit code.
+type Code int
+
+const (
+	CodeUnknown        Code = 1
+	CodeConfigMissing  Code = 10
+	CodeEditorFailed   Code = 11
+	CodeGitPushFailed  Code = 12
+	CodeGitPullFailed  Code = 13
+	CodeTemplateFailed Code = 14
+)
+
+// Error is a typed nb error with an exit code and remediation hint, so
+// printError can tell users what happened and what to do about it.
+type Error struct {
+	Code        Code
+	Message     string
+	Remediation string
+	Cause       error
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// ConfigMissing wraps a failure to resolve the notebook's configuration.
+func ConfigMissing(cause error) *Error {
+	return &Error{
+		Code:        CodeConfigMissing,
+		Message:     "could not read notebook configuration",
+		Remediation: "run `nb init` to create ~/.nb.yaml, or pass --config",
+		Cause:       cause,
+	}
+}
+
+// EditorFailed wraps a failure to launch the configured editor.
+func EditorFailed(cause error) *Error {
+	return &Error{
+		Code:        CodeEditorFailed,
+		Message:     "could not open the editor",
+		Remediation: "check that the `editor` config value points at an installed executable",
+		Cause:       cause,
+	}
+}
+
+// GitPushFailed wraps a failure to push the notebook repository.
+func GitPushFailed(cause error) *Error {
+	return &Error{
+		Code:        CodeGitPushFailed,
+		Message:     "could not push to the remote",
+		Remediation: "check the `remote` and `gitToken` config values, or your SSH agent",
+		Cause:       cause,
+	}
+}
+
+// GitPullFailed wraps a failure to pull the notebook repository.
+func GitPullFailed(cause error) *Error {
+	return &Error{
+		Code:        CodeGitPullFailed,
+		Message:     "could not pull from the remote",
+		Remediation: "resolve the divergent history with a system git client, then sync again",
+		Cause:       cause,
+	}
+}
+
+// TemplateFailed wraps a failure to load or render a note template.
+func TemplateFailed(cause error) *Error {
+	return &Error{
+		Code:        CodeTemplateFailed,
+		Message:     "could not render the note template",
+		Remediation: "check that the template exists in your templates directory and is valid",
+		Cause:       cause,
+	}
+}
@@ -8,16 +8,18 @@ import (
 
 	"github.com/t-eckert/nb/config"
 	"github.com/t-eckert/nb/editor"
+	"github.com/t-eckert/nb/templates"
 )
 
 const offset = 0
 
 type Config struct {
-	Offset int
+	Offset   int
+	Template string
 }
 
-func Log(open bool, args ...string) error {
-	config, err := parseArgs(args...)
+func Log(open bool, template string, args ...string) error {
+	config, err := parseArgs(template, args...)
 	if err != nil {
 		return fmt.Errorf("could not parse arguments: %v", err)
 	}
@@ -33,7 +35,7 @@ func Log(open bool, args ...string) error {
 	}
 
 	if !exists {
-		if err := create(path, config.Offset); err != nil {
+		if err := create(path, config.Template, config.Offset); err != nil {
 			return fmt.Errorf("could not create new log: %v", err)
 		}
 	}
@@ -48,9 +50,9 @@ func Log(open bool, args ...string) error {
 	return nil
 }
 
-func parseArgs(args ...string) (*Config, error) {
+func parseArgs(template string, args ...string) (*Config, error) {
 	if len(args) == 0 {
-		return &Config{Offset: 0}, nil
+		return &Config{Offset: 0, Template: template}, nil
 	}
 
 	offset, err := strconv.Atoi(args[0])
@@ -58,7 +60,7 @@ func parseArgs(args ...string) (*Config, error) {
 		return nil, err
 	}
 
-	return &Config{Offset: offset}, nil
+	return &Config{Offset: offset, Template: template}, nil
 }
 
 func configurePath(offset int) (string, error) {
@@ -84,28 +86,25 @@ func exists(path string) (bool, error) {
 	return false, nil
 }
 
-func create(path string, offset int) error {
-	f, err := os.Create(path)
+// create renders the named template for the day offset by offset days and
+// writes the result to path.
+func create(path, template string, offset int) error {
+	root, err := config.GetRootDir()
 	if err != nil {
 		return err
 	}
 
-	defer f.Close()
-
-	template := fmt.Sprintf(`# %s 
-
-## Tasks
-
-
-`, formateDateTitle(time.Now().Add(time.Duration(offset)*24*time.Hour)))
-
-	_, err = f.WriteString(template)
+	dir, err := templates.Dir(root)
+	if err != nil {
+		return err
+	}
 
+	content, err := templates.Render(dir, template, templates.DataFor(offset))
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return os.WriteFile(path, []byte(content), 0o644)
 }
 
 func date(offset int) time.Time {
@@ -115,7 +114,3 @@ func date(offset int) time.Time {
 func formatDate(t time.Time) string {
 	return t.Format("2006-01-02")
 }
-
-func formateDateTitle(t time.Time) string {
-	return t.Format("2 Jan 2006")
-}
@@ -0,0 +1,90 @@
+// Package builder walks a notebook's root directory and renders it into an
+// in-memory Site, similar to a static-site generator's build step.
+package builder
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/t-eckert/nb/serve/render"
+)
+
+// Site is an in-memory tree of a notebook's rendered Markdown pages.
+type Site struct {
+	Root  string
+	Pages []*render.Page
+}
+
+// Build walks root and renders every Markdown file under it into a Page.
+func Build(root string) (*Site, error) {
+	site := &Site{Root: root}
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		page, err := render.File(root, rel)
+		if err != nil {
+			return err
+		}
+
+		site.Pages = append(site.Pages, page)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(site.Pages, func(i, j int) bool {
+		return site.Pages[i].Path < site.Pages[j].Path
+	})
+
+	return site, nil
+}
+
+// Page looks up a page by its clean URL.
+func (s *Site) Page(url string) (*render.Page, bool) {
+	for _, page := range s.Pages {
+		if page.URL == url {
+			return page, true
+		}
+	}
+	return nil, false
+}
+
+type urlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc string `xml:"loc"`
+}
+
+// Sitemap renders a sitemap.xml body listing every page in the site.
+func (s *Site) Sitemap(domain string) ([]byte, error) {
+	set := urlSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+
+	for _, page := range s.Pages {
+		set.URLs = append(set.URLs, sitemapURL{Loc: "http://" + domain + page.URL})
+	}
+
+	body, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,34 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/t-eckert/nb/serve/render"
+)
+
+func TestSitemap(t *testing.T) {
+	site := &Site{
+		Pages: []*render.Page{
+			{Title: "First", Path: "Log/2024-01-15.md", URL: "/notes/Log/2024-01-15"},
+			{Title: "Second", Path: "note.md", URL: "/notes/note"},
+		},
+	}
+
+	body, err := site.Sitemap("example.com")
+	if err != nil {
+		t.Fatalf("Sitemap returned error: %v", err)
+	}
+
+	xml := string(body)
+	if !strings.HasPrefix(xml, `<?xml version="1.0" encoding="UTF-8"?>`) {
+		t.Errorf("expected sitemap to start with an XML header, got %q", xml)
+	}
+
+	for _, page := range site.Pages {
+		loc := "http://example.com" + page.URL
+		if !strings.Contains(xml, loc) {
+			t.Errorf("expected sitemap to contain %q, got %q", loc, xml)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package serve
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/t-eckert/nb/serve/builder"
+	"github.com/t-eckert/nb/serve/watch"
+)
+
+// reloadBroker fans a single reload signal out to every connected
+// /dev/reload SSE client.
+type reloadBroker struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+func newReloadBroker() *reloadBroker {
+	return &reloadBroker{subs: make(map[chan struct{}]struct{})}
+}
+
+func (b *reloadBroker) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch
+}
+
+func (b *reloadBroker) unsubscribe(ch chan struct{}) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+
+	close(ch)
+}
+
+func (b *reloadBroker) broadcast() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// watchAndRebuild rebuilds the site whenever the watcher reports a change
+// and notifies every subscribed reload client.
+func watchAndRebuild(root string, holder *siteHolder, watcher *watch.Watcher, reloads *reloadBroker) {
+	for range watcher.Events {
+		site, err := builder.Build(root)
+		if err != nil {
+			continue
+		}
+
+		holder.set(site)
+		reloads.broadcast()
+	}
+}
+
+// handleReload serves /dev/reload as an SSE stream, sending a "reload"
+// event each time the watched notebook changes.
+func handleReload(reloads *reloadBroker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		ch := reloads.subscribe()
+		defer reloads.unsubscribe(ch)
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ch:
+				fmt.Fprint(w, "data: reload\n\n")
+				flusher.Flush()
+			}
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package serve
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/t-eckert/nb/config"
+	"github.com/t-eckert/nb/serve/render"
+)
+
+// feedCache holds the last rendered Atom and RSS bodies, keyed by a cheap
+// signature of the notebook tree (path, size, and mod time of every
+// Markdown file). Repeat requests only pay the cost of walking the tree
+// and comparing signatures, not of re-rendering every file.
+type feedCache struct {
+	mu        sync.Mutex
+	signature string
+	atom      []byte
+	rss       []byte
+	etag      string
+}
+
+var cache feedCache
+
+func (c *feedCache) get(root, domain, kind string) ([]byte, string, error) {
+	sig, err := treeSignature(root)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if sig != c.signature {
+		pages, err := collectFeedPages(root)
+		if err != nil {
+			return nil, "", err
+		}
+
+		atomBody, err := renderAtomFeed(domain, pages)
+		if err != nil {
+			return nil, "", err
+		}
+
+		rssBody, err := renderRSSFeed(domain, pages)
+		if err != nil {
+			return nil, "", err
+		}
+
+		c.signature = sig
+		c.atom = atomBody
+		c.rss = rssBody
+		c.etag = contentETag(append(atomBody, rssBody...))
+	}
+
+	if kind == "rss" {
+		return c.rss, c.etag, nil
+	}
+	return c.atom, c.etag, nil
+}
+
+// treeSignature is a cheap fingerprint of every Markdown file under root,
+// based on path, size, and mod time, not file content.
+func treeSignature(root string) (string, error) {
+	h := sha256.New()
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		fmt.Fprintf(h, "%s:%d:%d\n", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func contentETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:]))
+}
+
+// feedPage pairs a rendered page with the timestamps needed for a feed
+// entry, which render.Page does not itself carry.
+type feedPage struct {
+	*render.Page
+	Created time.Time
+	Updated time.Time
+}
+
+// collectFeedPages walks root for Markdown files and renders each one.
+func collectFeedPages(root string) ([]feedPage, error) {
+	var pages []feedPage
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		page, err := render.File(root, rel)
+		if err != nil {
+			return err
+		}
+
+		created := info.ModTime()
+		if date, ok := page.FrontMatter["date"].(string); ok {
+			if t, err := time.Parse("2006-01-02", date); err == nil {
+				created = t
+			}
+		}
+
+		pages = append(pages, feedPage{Page: page, Created: created, Updated: info.ModTime()})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].Updated.After(pages[j].Updated)
+	})
+
+	return pages, nil
+}
+
+// tagURI builds a tag: URI (RFC 4151) for a feed entry from the configured
+// domain, the entry's Created date, and its path. Created is the
+// front-matter "date" when present and otherwise the file's modification
+// time, so an entry without a front-matter date gets a new ID each time its
+// file is edited.
+func tagURI(domain string, created time.Time, path string) string {
+	return fmt.Sprintf("tag:%s,%s:%s", domain, created.Format("2006-01-02"), path)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",cdata"`
+}
+
+func renderAtomFeed(domain string, pages []feedPage) ([]byte, error) {
+	updated := time.Now()
+	if len(pages) > 0 {
+		updated = pages[0].Updated
+	}
+
+	feed := atomFeed{
+		Title:   "Notebook",
+		ID:      tagURI(domain, updated, "/"),
+		Updated: updated.Format(time.RFC3339),
+		Link:    atomLink{Href: fmt.Sprintf("http://%s/feed.atom", domain), Rel: "self"},
+	}
+
+	for _, page := range pages {
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   page.Title,
+			ID:      tagURI(domain, page.Created, page.Path),
+			Updated: page.Updated.Format(time.RFC3339),
+			Content: atomContent{Type: "html", Body: page.HTML},
+		})
+	}
+
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+}
+
+func renderRSSFeed(domain string, pages []feedPage) ([]byte, error) {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       "Notebook",
+			Link:        fmt.Sprintf("http://%s/feed.rss", domain),
+			Description: "Notes from the notebook",
+		},
+	}
+
+	for _, page := range pages {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       page.Title,
+			GUID:        tagURI(domain, page.Created, page.Path),
+			PubDate:     page.Updated.Format(time.RFC1123Z),
+			Description: page.HTML,
+		})
+	}
+
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+func handleAtomFeed(w http.ResponseWriter, r *http.Request) {
+	serveFeed(w, r, "atom", "application/atom+xml; charset=utf-8")
+}
+
+func handleRSSFeed(w http.ResponseWriter, r *http.Request) {
+	serveFeed(w, r, "rss", "application/rss+xml; charset=utf-8")
+}
+
+func serveFeed(w http.ResponseWriter, r *http.Request, kind, contentType string) {
+	root, err := config.GetRootDir()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	domain, err := config.GetDomain()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	body, etag, err := cache.get(root, domain, kind)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Type", contentType)
+	w.Write(body)
+}
@@ -0,0 +1,97 @@
+// Package render converts a notebook's Markdown files into HTML pages,
+// extracting YAML front matter and deriving the clean URL each page is
+// served at.
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"gopkg.in/yaml.v3"
+)
+
+// Page is a single rendered Markdown file.
+type Page struct {
+	Title       string
+	Path        string // path relative to the notebook root, using "/" separators
+	URL         string // clean URL the page is served at, e.g. "/notes/Log/2024-01-15"
+	HTML        string
+	FrontMatter map[string]interface{}
+}
+
+// File reads and renders the Markdown file at path, relative to root.
+func File(root, path string) (*Page, error) {
+	content, err := os.ReadFile(filepath.Join(root, path))
+	if err != nil {
+		return nil, err
+	}
+
+	return Bytes(path, content)
+}
+
+// Bytes renders Markdown content into a Page. path is only used to derive
+// the page's title and URL; it is not read from disk.
+func Bytes(path string, content []byte) (*Page, error) {
+	frontMatter, body := splitFrontMatter(content)
+
+	var html bytes.Buffer
+	if err := goldmark.Convert(body, &html); err != nil {
+		return nil, err
+	}
+
+	return &Page{
+		Title:       title(frontMatter, body, path),
+		Path:        filepath.ToSlash(path),
+		URL:         URL(path),
+		HTML:        html.String(),
+		FrontMatter: frontMatter,
+	}, nil
+}
+
+// URL maps a Markdown file's path, relative to the notebook root, to the
+// clean URL it is served at: "Log/2024-01-15.md" -> "/notes/Log/2024-01-15".
+func URL(path string) string {
+	clean := strings.TrimSuffix(filepath.ToSlash(path), filepath.Ext(path))
+	return "/notes/" + clean
+}
+
+// splitFrontMatter separates a leading "---" YAML block from the rest of a
+// Markdown file. It returns an empty map if there is no front matter.
+func splitFrontMatter(content []byte) (map[string]interface{}, []byte) {
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return map[string]interface{}{}, content
+	}
+
+	rest := content[len("---\n"):]
+	end := bytes.Index(rest, []byte("\n---\n"))
+	if end == -1 {
+		return map[string]interface{}{}, content
+	}
+
+	var frontMatter map[string]interface{}
+	if err := yaml.Unmarshal(rest[:end], &frontMatter); err != nil {
+		return map[string]interface{}{}, content
+	}
+
+	return frontMatter, rest[end+len("\n---\n"):]
+}
+
+// title prefers a front-matter "title", then the first "#" heading, then
+// the file's base name.
+func title(frontMatter map[string]interface{}, body []byte, path string) string {
+	if title, ok := frontMatter["title"].(string); ok && title != "" {
+		return title
+	}
+
+	for _, line := range strings.Split(string(body), "\n") {
+		if heading, ok := strings.CutPrefix(strings.TrimSpace(line), "# "); ok {
+			return heading
+		}
+	}
+
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
@@ -0,0 +1,65 @@
+package render
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestURL(t *testing.T) {
+	cases := map[string]string{
+		"Log/2024-01-15.md": "/notes/Log/2024-01-15",
+		"index.md":          "/notes/index",
+	}
+
+	for path, expected := range cases {
+		actual := URL(path)
+		if expected != actual {
+			t.Errorf("URL(%q): expected %s, got %s", path, expected, actual)
+		}
+	}
+}
+
+func TestBytesFrontMatterTitle(t *testing.T) {
+	content := []byte("---\ntitle: Hello World\n---\n# Ignored Heading\n\nBody text.\n")
+
+	page, err := Bytes("Log/2024-01-15.md", content)
+	if err != nil {
+		t.Fatalf("Bytes returned error: %v", err)
+	}
+
+	if page.Title != "Hello World" {
+		t.Errorf("Title: expected %q, got %q", "Hello World", page.Title)
+	}
+	if page.URL != "/notes/Log/2024-01-15" {
+		t.Errorf("URL: expected %q, got %q", "/notes/Log/2024-01-15", page.URL)
+	}
+	if !strings.Contains(page.HTML, "Body text.") {
+		t.Errorf("HTML: expected rendered body to contain %q, got %q", "Body text.", page.HTML)
+	}
+}
+
+func TestBytesHeadingTitle(t *testing.T) {
+	content := []byte("# Heading Title\n\nBody text.\n")
+
+	page, err := Bytes("note.md", content)
+	if err != nil {
+		t.Fatalf("Bytes returned error: %v", err)
+	}
+
+	if page.Title != "Heading Title" {
+		t.Errorf("Title: expected %q, got %q", "Heading Title", page.Title)
+	}
+}
+
+func TestBytesFilenameTitle(t *testing.T) {
+	content := []byte("Just some prose, no heading.\n")
+
+	page, err := Bytes("note.md", content)
+	if err != nil {
+		t.Fatalf("Bytes returned error: %v", err)
+	}
+
+	if page.Title != "note" {
+		t.Errorf("Title: expected %q, got %q", "note", page.Title)
+	}
+}
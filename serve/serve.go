@@ -3,48 +3,172 @@ package serve
 import (
 	"fmt"
 	"html/template"
-	"log"
 	"net/http"
 	"path/filepath"
+	"strings"
+	"sync"
 
 	"github.com/t-eckert/nb/config"
+	"github.com/t-eckert/nb/serve/builder"
+	"github.com/t-eckert/nb/serve/watch"
 )
 
-func Serve() error {
+// siteHolder lets handlers read the current build of the notebook while a
+// dev-mode watcher rebuilds it in the background.
+type siteHolder struct {
+	mu   sync.RWMutex
+	site *builder.Site
+}
+
+func (h *siteHolder) get() *builder.Site {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.site
+}
+
+func (h *siteHolder) set(site *builder.Site) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.site = site
+}
+
+// Serve starts the notebook's HTTP server. When dev is true, it also
+// watches the notebook root for changes, rebuilds the site, and pushes
+// reload events to the browser over SSE.
+func Serve(dev bool) error {
 	root, err := config.GetRootDir()
 	if err != nil {
 		return err
 	}
 
+	site, err := builder.Build(root)
+	if err != nil {
+		return err
+	}
+
+	holder := &siteHolder{site: site}
+
 	static := http.FileServer(http.Dir("./static"))
 	http.Handle("/static/", http.StripPrefix("/static/", static))
 
-	fs := http.FileServer(http.Dir(root))
-	http.Handle("/files/", http.StripPrefix("/files/", fs))
+	http.HandleFunc("/feed.atom", handleAtomFeed)
+	http.HandleFunc("/feed.rss", handleRSSFeed)
+
+	http.HandleFunc("/sitemap.xml", handleSitemap(holder))
+	http.HandleFunc("/notes/", handleNotes(holder, dev))
 
-	http.HandleFunc("/", serveUI)
+	http.HandleFunc("/", handleIndex(holder, dev))
+
+	if dev {
+		watcher, err := watch.New(root)
+		if err != nil {
+			return err
+		}
+		defer watcher.Close()
+
+		reloads := newReloadBroker()
+		http.HandleFunc("/dev/reload", handleReload(reloads))
+
+		go watchAndRebuild(root, holder, watcher, reloads)
+	}
 
-	port := fmt.Sprintf(":%d", config.Port)
+	p, err := config.GetPort()
+	if err != nil {
+		return err
+	}
+
+	port := fmt.Sprintf(":%d", p)
 	fmt.Printf("Serving on http://localhost%s", port)
 	if err := http.ListenAndServe(port, nil); err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("could not start server: %w", err)
 	}
 
 	return nil
 }
 
-type tpl struct {
-	Files string
+func handleSitemap(holder *siteHolder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		domain, err := config.GetDomain()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		body, err := holder.get().Sitemap(domain)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.Write(body)
+	}
 }
 
-func serveUI(w http.ResponseWriter, r *http.Request) {
+func handleNotes(holder *siteHolder, dev bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		url := strings.TrimSuffix(r.URL.Path, "/")
 
-	files := `files!`
+		page, ok := holder.get().Page(url)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
 
-	t := tpl{files}
+		index := filepath.Join("templates", "index.html")
+		tmpl, err := template.ParseFiles(index)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 
-	index := filepath.Join("templates", "index.html")
+		data := struct {
+			Title string
+			Body  template.HTML
+			Dev   bool
+		}{
+			Title: page.Title,
+			Body:  template.HTML(page.HTML),
+			Dev:   dev,
+		}
 
-	tmpl, _ := template.ParseFiles(index)
-	tmpl.ExecuteTemplate(w, "index", t)
+		tmpl.ExecuteTemplate(w, "index", data)
+	}
+}
+
+// handleIndex serves the notebook's home page: a list of links to every
+// rendered page in the site.
+func handleIndex(holder *siteHolder, dev bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var body strings.Builder
+		body.WriteString("<ul>")
+		for _, page := range holder.get().Pages {
+			fmt.Fprintf(&body, `<li><a href="%s">%s</a></li>`, template.HTMLEscapeString(page.URL), template.HTMLEscapeString(page.Title))
+		}
+		body.WriteString("</ul>")
+
+		index := filepath.Join("templates", "index.html")
+		tmpl, err := template.ParseFiles(index)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		data := struct {
+			Title string
+			Body  template.HTML
+			Dev   bool
+		}{
+			Title: "Notebook",
+			Body:  template.HTML(body.String()),
+			Dev:   dev,
+		}
+
+		tmpl.ExecuteTemplate(w, "index", data)
+	}
 }
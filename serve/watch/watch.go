@@ -0,0 +1,76 @@
+// Package watch notifies subscribers when files under a notebook's root
+// directory change, so a dev server can rebuild and trigger a reload.
+package watch
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher emits an event on Events whenever a file under its root is
+// created, written, removed, or renamed. Changes that arrive while an event
+// is still pending are merged into that event.
+type Watcher struct {
+	fsWatcher *fsnotify.Watcher
+	Events    chan struct{}
+}
+
+// New watches root and every directory beneath it for changes. Directories
+// created after New returns are not watched.
+func New(root string) (*Watcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return fsWatcher.Add(path)
+		}
+		return nil
+	})
+	if err != nil {
+		fsWatcher.Close()
+		return nil, err
+	}
+
+	w := &Watcher{
+		fsWatcher: fsWatcher,
+		Events:    make(chan struct{}, 1),
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+func (w *Watcher) run() {
+	for {
+		select {
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
+				select {
+				case w.Events <- struct{}{}:
+				default:
+				}
+			}
+		case _, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// Close stops the underlying filesystem watcher.
+func (w *Watcher) Close() error {
+	return w.fsWatcher.Close()
+}
@@ -0,0 +1,257 @@
+// Package sync commits and pushes the notebook's git repository using
+// go-git, so `nb sync` does not depend on a system git binary.
+package sync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	gitssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
+
+	"github.com/t-eckert/nb/config"
+)
+
+// Repo wraps the notebook's git repository.
+type Repo struct {
+	repo *git.Repository
+}
+
+// Open opens the git repository at root.
+func Open(root string) (*Repo, error) {
+	repo, err := git.PlainOpen(root)
+	if err != nil {
+		return nil, fmt.Errorf("could not open notebook repository: %w", err)
+	}
+
+	return &Repo{repo: repo}, nil
+}
+
+// Status reports the notebook repository's dirty files, merge conflicts,
+// and how far its current branch has diverged from its remote-tracking
+// branch.
+type Status struct {
+	Dirty     []string
+	Conflicts []string
+	Ahead     int
+	Behind    int
+}
+
+// Status reports the working tree's dirty files and the current branch's
+// ahead/behind counts against its remote-tracking branch.
+func (r *Repo) Status() (*Status, error) {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	fileStatus, err := wt.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{}
+	for path, s := range fileStatus {
+		if s.Staging == git.UpdatedButUnmerged || s.Worktree == git.UpdatedButUnmerged {
+			status.Conflicts = append(status.Conflicts, path)
+			continue
+		}
+		status.Dirty = append(status.Dirty, path)
+	}
+
+	ahead, behind, err := r.aheadBehind()
+	if err != nil {
+		return nil, err
+	}
+	status.Ahead = ahead
+	status.Behind = behind
+
+	return status, nil
+}
+
+func (r *Repo) aheadBehind() (int, int, error) {
+	head, err := r.repo.Head()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	remoteRef, err := r.repo.Reference(plumbing.NewRemoteReferenceName("origin", head.Name().Short()), true)
+	if err != nil {
+		// No remote-tracking branch to compare against.
+		return 0, 0, nil
+	}
+
+	ahead, err := commitsNotIn(r.repo, head.Hash(), remoteRef.Hash())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	behind, err := commitsNotIn(r.repo, remoteRef.Hash(), head.Hash())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return ahead, behind, nil
+}
+
+// commitsNotIn counts commits reachable from "from" that are not reachable
+// from "base".
+func commitsNotIn(repo *git.Repository, from, base plumbing.Hash) (int, error) {
+	if from == base {
+		return 0, nil
+	}
+
+	baseCommit, err := repo.CommitObject(base)
+	if err != nil {
+		return 0, err
+	}
+
+	ancestors := map[plumbing.Hash]bool{}
+	err = object.NewCommitIterBSF(baseCommit, nil, nil).ForEach(func(c *object.Commit) error {
+		ancestors[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	fromCommit, err := repo.CommitObject(from)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = object.NewCommitIterBSF(fromCommit, nil, nil).ForEach(func(c *object.Commit) error {
+		if !ancestors[c.Hash] {
+			count++
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+// Commit stages every change in the worktree, including new files, and
+// commits it with message. It reports false if there was nothing to commit.
+func (r *Repo) Commit(message string) (bool, error) {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return false, err
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return false, err
+	}
+	if status.IsClean() {
+		return false, nil
+	}
+
+	// CommitOptions.All only stages Modified and Deleted paths; it skips
+	// Untracked files, so new notes and logs would never make it into the
+	// commit. Add those explicitly before committing.
+	for path, s := range status {
+		if s.Worktree == git.Untracked {
+			if _, err := wt.Add(path); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if _, err := wt.Commit(message, &git.CommitOptions{All: true}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Pull fetches from origin and fast-forwards the current branch onto it.
+// go-git only supports fast-forward pulls; a history that has diverged
+// returns an error, and the user is expected to resolve it with a system
+// git client before syncing again.
+func (r *Repo) Pull() error {
+	wt, err := r.repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	auth, err := resolveAuth()
+	if err != nil {
+		return err
+	}
+
+	err = wt.Pull(&git.PullOptions{RemoteName: "origin", Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+
+	return nil
+}
+
+// Push pushes the current branch to origin.
+func (r *Repo) Push() error {
+	auth, err := resolveAuth()
+	if err != nil {
+		return err
+	}
+
+	err = r.repo.Push(&git.PushOptions{RemoteName: "origin", Auth: auth})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+
+	return nil
+}
+
+// resolveAuth picks an auth method from the configured remote: an SSH
+// agent for ssh:// and git@ remotes, or a token from config for HTTPS
+// remotes.
+func resolveAuth() (transport.AuthMethod, error) {
+	remote, err := config.GetRemote()
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(remote, "git@") || strings.HasPrefix(remote, "ssh://") {
+		return gitssh.NewSSHAgentAuth("git")
+	}
+
+	token, err := config.GetGitToken()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, nil
+	}
+
+	return &githttp.BasicAuth{Username: "nb", Password: token}, nil
+}
+
+// Message renders the configured commit message template for today.
+func Message() (string, error) {
+	tmplText, err := config.GetSyncMessageTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New("message").Parse(tmplText)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	data := struct{ Date string }{Date: time.Now().Format("2006-01-02")}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
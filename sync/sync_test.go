@@ -0,0 +1,27 @@
+package sync
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/t-eckert/nb/config"
+)
+
+func TestMain(m *testing.M) {
+	config.SetDefaults()
+	os.Exit(m.Run())
+}
+
+func TestMessage(t *testing.T) {
+	expected := "Sync " + time.Now().Format("2006-01-02")
+
+	actual, err := Message()
+	if err != nil {
+		t.Fatalf("Message returned error: %v", err)
+	}
+
+	if expected != actual {
+		t.Errorf("expected: %s\nactual: %s", expected, actual)
+	}
+}
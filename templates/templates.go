@@ -0,0 +1,77 @@
+// Package templates renders a notebook's Markdown templates, loaded from
+// disk, into notes. Templates may contain YAML front matter, which is
+// passed through untouched for serve's render package to parse later.
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/t-eckert/nb/config"
+)
+
+// Data is the set of variables available inside a template.
+type Data struct {
+	Date      string
+	DateTitle string
+	Weekday   string
+	Offset    int
+	Yesterday string
+	Tomorrow  string
+}
+
+// DataFor builds template Data for the day offset by offset days from
+// today.
+func DataFor(offset int) Data {
+	day := time.Now().Add(time.Duration(offset) * 24 * time.Hour)
+
+	return Data{
+		Date:      day.Format("2006-01-02"),
+		DateTitle: day.Format("2 Jan 2006"),
+		Weekday:   day.Weekday().String(),
+		Offset:    offset,
+		Yesterday: day.AddDate(0, 0, -1).Format("2006-01-02"),
+		Tomorrow:  day.AddDate(0, 0, 1).Format("2006-01-02"),
+	}
+}
+
+// Dir returns the directory templates are loaded from: the `templatesDir`
+// config override if set, otherwise <root>/templates.
+func Dir(root string) (string, error) {
+	override, err := config.GetTemplatesDir()
+	if err != nil {
+		return "", err
+	}
+	if override != "" {
+		return override, nil
+	}
+
+	return filepath.Join(root, "templates"), nil
+}
+
+// Render loads <dir>/<name>.md and executes it as a text/template with
+// data, returning the rendered content.
+func Render(dir, name string, data Data) (string, error) {
+	path := filepath.Join(dir, name+".md")
+
+	tmplText, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read template %q: %w", name, err)
+	}
+
+	tmpl, err := template.New(name).Parse(string(tmplText))
+	if err != nil {
+		return "", fmt.Errorf("could not parse template %q: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("could not render template %q: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,48 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDataForOffsetsAreConsistent(t *testing.T) {
+	today := DataFor(0)
+	tomorrow := DataFor(1)
+	yesterday := DataFor(-1)
+
+	if today.Tomorrow != tomorrow.Date {
+		t.Errorf("today.Tomorrow: expected %s, got %s", tomorrow.Date, today.Tomorrow)
+	}
+	if today.Yesterday != yesterday.Date {
+		t.Errorf("today.Yesterday: expected %s, got %s", yesterday.Date, today.Yesterday)
+	}
+}
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	tmpl := "# {{.DateTitle}}\n\n{{.Weekday}}\n"
+	if err := os.WriteFile(filepath.Join(dir, "daily.md"), []byte(tmpl), 0644); err != nil {
+		t.Fatalf("could not write template fixture: %v", err)
+	}
+
+	data := Data{Date: "2024-01-15", DateTitle: "15 Jan 2024", Weekday: "Monday"}
+
+	actual, err := Render(dir, "daily", data)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	expected := "# 15 Jan 2024\n\nMonday\n"
+	if expected != actual {
+		t.Errorf("expected: %q\nactual: %q", expected, actual)
+	}
+}
+
+func TestRenderMissingTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := Render(dir, "missing", Data{}); err == nil {
+		t.Error("expected an error for a missing template file, got nil")
+	}
+}